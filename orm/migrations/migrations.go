@@ -0,0 +1,270 @@
+// Package migrations provides ordered, versioned schema migrations for
+// consumers of the ORM layer in orm/sql.
+package migrations
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	ormsql "github.com/riskibarqy/barqy-orm/orm/sql"
+)
+
+// Migrator is a single migration step, run inside a transaction.
+type Migrator func(ctx context.Context, tx *ormsql.Tx) error
+
+// migration pairs a registered name with its up/down steps.
+type migration struct {
+	name string
+	up   Migrator
+	down Migrator
+}
+
+// registry holds every migration registered via Register, keyed by name.
+// Go does not guarantee init() order across files, so registration order
+// must never be relied on - Migrate and Rollback always sort by name.
+var registry = map[string]*migration{}
+
+// Register adds a migration to the registry. Names are conventionally
+// prefixed with a zero-padded sequence number (e.g. "0001_create_users")
+// so that lexicographic sorting matches intended run order.
+func Register(name string, up, down Migrator) {
+	registry[name] = &migration{name: name, up: up, down: down}
+}
+
+// RegisterSQL registers a migration whose up/down steps are plain .sql
+// files embedded via embed.FS, e.g.:
+//
+//	//go:embed sql/*.sql
+//	var sqlFiles embed.FS
+//	migrations.RegisterSQL("0001_create_users", sqlFiles, "sql/0001_up.sql", "sql/0001_down.sql")
+func RegisterSQL(name string, files embed.FS, upPath, downPath string) {
+	Register(name, sqlFileMigrator(files, upPath), sqlFileMigrator(files, downPath))
+}
+
+// sqlFileMigrator returns a Migrator that reads path from files and runs
+// its contents as a single statement within the migration's transaction.
+func sqlFileMigrator(files embed.FS, path string) Migrator {
+	return func(ctx context.Context, tx *ormsql.Tx) error {
+		contents, err := files.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read migration file %s: %w", path, err)
+		}
+		if _, err := tx.Exec(string(contents)); err != nil {
+			return fmt.Errorf("failed to execute migration file %s: %w", path, err)
+		}
+		return nil
+	}
+}
+
+// sortedNames returns every registered migration name, sorted
+// lexicographically.
+func sortedNames() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ensureSchemaTable creates the schema_migrations bookkeeping table if it
+// does not already exist.
+func ensureSchemaTable(ctx context.Context) error {
+	_, err := ormsql.GetDB().ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version TEXT PRIMARY KEY,
+		applied_at TIMESTAMP
+	)`)
+	if err != nil {
+		return fmt.Errorf("migrations: failed to create schema_migrations table: %w", err)
+	}
+	return nil
+}
+
+// appliedVersions returns the set of migration names already recorded in
+// schema_migrations.
+func appliedVersions(ctx context.Context) (map[string]bool, error) {
+	rows, err := ormsql.GetDB().QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("migrations: failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[string]bool{}
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("migrations: failed to scan schema_migrations row: %w", err)
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+// Migrate runs every registered migration that has not yet been applied,
+// in lexicographic order, each inside its own transaction. The version row
+// is inserted in the same transaction as the migration's Up step so a
+// crash mid-migration never leaves a partially-applied, unrecorded step.
+func Migrate(ctx context.Context) error {
+	if err := ensureSchemaTable(ctx); err != nil {
+		return err
+	}
+
+	applied, err := appliedVersions(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range sortedNames() {
+		if applied[name] {
+			continue
+		}
+
+		m := registry[name]
+		err := ormsql.WithTx(ctx, nil, func(tx *ormsql.Tx) error {
+			if err := m.up(ctx, tx); err != nil {
+				return fmt.Errorf("up failed: %w", err)
+			}
+			if _, err := tx.Exec("INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)", name, time.Now()); err != nil {
+				return fmt.Errorf("failed to record migration: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("migrations: %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// Rollback reverts the most recently applied steps migrations, in reverse
+// lexicographic order.
+func Rollback(ctx context.Context, steps int) error {
+	if err := ensureSchemaTable(ctx); err != nil {
+		return err
+	}
+
+	rows, err := ormsql.GetDB().QueryContext(ctx, ormsql.Rebind("SELECT version FROM schema_migrations ORDER BY version DESC LIMIT ?"), steps)
+	if err != nil {
+		return fmt.Errorf("migrations: failed to read schema_migrations: %w", err)
+	}
+	var toRevert []string
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			rows.Close()
+			return fmt.Errorf("migrations: failed to scan schema_migrations row: %w", err)
+		}
+		toRevert = append(toRevert, version)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("migrations: failed to iterate schema_migrations: %w", err)
+	}
+
+	for _, name := range toRevert {
+		m, ok := registry[name]
+		if !ok {
+			return fmt.Errorf("migrations: applied version %q is not registered, cannot roll back", name)
+		}
+
+		err := ormsql.WithTx(ctx, nil, func(tx *ormsql.Tx) error {
+			if err := m.down(ctx, tx); err != nil {
+				return fmt.Errorf("down failed: %w", err)
+			}
+			if _, err := tx.Exec("DELETE FROM schema_migrations WHERE version = ?", name); err != nil {
+				return fmt.Errorf("failed to unrecord migration: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("migrations: rollback %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// StatusEntry describes one registered migration's applied state.
+type StatusEntry struct {
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Status reports every registered migration and whether it has been
+// applied.
+func Status(ctx context.Context) ([]StatusEntry, error) {
+	if err := ensureSchemaTable(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := ormsql.GetDB().QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("migrations: failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	appliedAt := map[string]time.Time{}
+	for rows.Next() {
+		var version string
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, fmt.Errorf("migrations: failed to scan schema_migrations row: %w", err)
+		}
+		appliedAt[version] = at
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("migrations: failed to iterate schema_migrations: %w", err)
+	}
+
+	var entries []StatusEntry
+	for _, name := range sortedNames() {
+		at, ok := appliedAt[name]
+		entries = append(entries, StatusEntry{Name: name, Applied: ok, AppliedAt: at})
+	}
+	return entries, nil
+}
+
+// Run is a small CLI entry point so consumers can drive migrations with
+// `go run ./cmd/migrate migrate|rollback [n]|status`. args is typically
+// os.Args.
+func Run(args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("usage: %s migrate|rollback [steps]|status", args[0])
+	}
+
+	ctx := context.Background()
+
+	switch args[1] {
+	case "migrate":
+		return Migrate(ctx)
+	case "rollback":
+		steps := 1
+		if len(args) > 2 {
+			if _, err := fmt.Sscanf(args[2], "%d", &steps); err != nil {
+				return fmt.Errorf("invalid steps %q: %w", args[2], err)
+			}
+		}
+		return Rollback(ctx, steps)
+	case "status":
+		entries, err := Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if e.Applied {
+				fmt.Fprintf(os.Stdout, "%s\tapplied\t%s\n", e.Name, e.AppliedAt.Format(time.RFC3339))
+			} else {
+				fmt.Fprintf(os.Stdout, "%s\tpending\n", e.Name)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown migrations command %q", args[1])
+	}
+}
@@ -12,17 +12,17 @@ func Connect(driverName, dsn string) error {
 	var err error
 
 	// Check if the provided driverName is supported before opening the connection.
-	switch driverName {
-	case "mysql", "postgres", "sqlite3", "mssql":
-		// If a valid driver name is passed, open the connection
-		db, err = sql.Open(driverName, dsn)
-		if err != nil {
-			return fmt.Errorf("failed to open database connection: %w", err)
-		}
-	default:
+	d, ok := dialects[driverName]
+	if !ok {
 		return fmt.Errorf("unsupported driver %s", driverName)
 	}
 
+	db, err = sql.Open(driverName, dsn)
+	if err != nil {
+		return fmt.Errorf("failed to open database connection: %w", err)
+	}
+	dialect = d
+
 	if err = db.Ping(); err != nil {
 		return fmt.Errorf("failed to ping database: %w", err)
 	}
@@ -42,3 +42,10 @@ func Close() error {
 	}
 	return nil
 }
+
+// Rebind rewrites a `?`-placeholder query into the active connection's
+// driver-native bind variable syntax, for callers outside this package
+// that build raw SQL (e.g. the migrations subpackage).
+func Rebind(query string) string {
+	return dialect.Rebind(query)
+}
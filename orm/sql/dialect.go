@@ -0,0 +1,177 @@
+package sql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Dialect abstracts the SQL differences between database drivers: bind
+// variable syntax, identifier quoting, pagination, and how to read back an
+// auto-generated primary key.
+type Dialect interface {
+	// Rebind rewrites a query built with `?` placeholders into the driver's
+	// native bind variable syntax.
+	Rebind(query string) string
+	// Quote wraps ident in the driver's identifier-quoting characters.
+	Quote(ident string) string
+	// LimitOffset renders a LIMIT/OFFSET clause for the driver, or "" when
+	// limit <= 0. The LIMIT-style dialects omit the OFFSET portion when
+	// offset <= 0; mssql always renders "OFFSET m ROWS FETCH NEXT n ROWS ONLY".
+	LimitOffset(limit, offset int) string
+	// Returning renders a clause appended to INSERT statements to read back
+	// pk, or "" if the driver has no such clause (it must fall back to
+	// LastInsertId instead).
+	Returning(pk string) string
+	// IsRetryable reports whether err represents a transient busy or
+	// serialization conflict that WithTx should retry rather than surface.
+	IsRetryable(err error) bool
+}
+
+// dialects maps a driver name, as passed to Connect, to its Dialect.
+var dialects = map[string]Dialect{
+	"mysql":    mysqlDialect{},
+	"postgres": postgresDialect{},
+	"sqlite3":  sqlite3Dialect{},
+	"mssql":    mssqlDialect{},
+}
+
+// dialect is the Dialect selected by Connect for the active *sql.DB.
+var dialect Dialect = mysqlDialect{}
+
+// rebindQuery walks query, skipping over quoted string literals and
+// `--`/`/* */` comments, and replaces each unescaped `?` with the bind
+// variable syntax produced by next. This mirrors the rebinding algorithm
+// used by jmoiron/sqlx.
+func rebindQuery(query string, next func(n int) string) string {
+	var b strings.Builder
+	n := 0
+
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"':
+			quote := c
+			b.WriteByte(c)
+			i++
+			for i < len(query) {
+				b.WriteByte(query[i])
+				if query[i] == quote {
+					break
+				}
+				i++
+			}
+		case c == '-' && i+1 < len(query) && query[i+1] == '-':
+			for i < len(query) && query[i] != '\n' {
+				b.WriteByte(query[i])
+				i++
+			}
+			if i < len(query) {
+				b.WriteByte(query[i])
+			}
+		case c == '/' && i+1 < len(query) && query[i+1] == '*':
+			end := strings.Index(query[i:], "*/")
+			if end == -1 {
+				b.WriteString(query[i:])
+				i = len(query)
+				break
+			}
+			b.WriteString(query[i : i+end+2])
+			i += end + 1
+		case c == '?':
+			n++
+			b.WriteString(next(n))
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String()
+}
+
+// mysqlDialect and sqlite3Dialect both use `?` placeholders natively.
+type mysqlDialect struct{}
+
+func (mysqlDialect) Rebind(query string) string { return query }
+func (mysqlDialect) Quote(ident string) string  { return "`" + ident + "`" }
+func (mysqlDialect) LimitOffset(limit, offset int) string {
+	return limitOffsetClause(limit, offset)
+}
+func (mysqlDialect) Returning(pk string) string { return "" }
+func (mysqlDialect) IsRetryable(err error) bool {
+	return containsAny(err, "Error 1213", "Deadlock found", "Error 1205", "Lock wait timeout")
+}
+
+type sqlite3Dialect struct{}
+
+func (sqlite3Dialect) Rebind(query string) string { return query }
+func (sqlite3Dialect) Quote(ident string) string  { return `"` + ident + `"` }
+func (sqlite3Dialect) LimitOffset(limit, offset int) string {
+	return limitOffsetClause(limit, offset)
+}
+func (sqlite3Dialect) Returning(pk string) string { return "" }
+func (sqlite3Dialect) IsRetryable(err error) bool {
+	return containsAny(err, "SQLITE_BUSY", "database is locked")
+}
+
+// postgresDialect uses `$1, $2, ...` and supports RETURNING.
+type postgresDialect struct{}
+
+func (postgresDialect) Rebind(query string) string {
+	return rebindQuery(query, func(n int) string { return "$" + strconv.Itoa(n) })
+}
+func (postgresDialect) Quote(ident string) string { return `"` + ident + `"` }
+func (postgresDialect) LimitOffset(limit, offset int) string {
+	return limitOffsetClause(limit, offset)
+}
+func (postgresDialect) Returning(pk string) string { return "RETURNING " + pk }
+func (postgresDialect) IsRetryable(err error) bool {
+	return containsAny(err, "SQLSTATE 40001", "SQLSTATE 40P01", "could not serialize access", "deadlock detected")
+}
+
+// mssqlDialect uses `@p1, @p2, ...` and OFFSET/FETCH pagination.
+type mssqlDialect struct{}
+
+func (mssqlDialect) Rebind(query string) string {
+	return rebindQuery(query, func(n int) string { return "@p" + strconv.Itoa(n) })
+}
+func (mssqlDialect) Quote(ident string) string { return "[" + ident + "]" }
+func (mssqlDialect) LimitOffset(limit, offset int) string {
+	if limit <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("OFFSET %d ROWS FETCH NEXT %d ROWS ONLY", offset, limit)
+}
+func (mssqlDialect) Returning(pk string) string { return "" }
+func (mssqlDialect) IsRetryable(err error) bool {
+	return containsAny(err, "deadlock", "Transaction (Process ID")
+}
+
+// containsAny reports whether err's message contains any of the markers,
+// the simplest portable way to classify driver errors without importing
+// each driver's package just for its error/code types.
+func containsAny(err error, markers ...string) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	for _, m := range markers {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// limitOffsetClause renders the common `LIMIT n OFFSET m` form shared by
+// mysql, postgres, and sqlite3.
+func limitOffsetClause(limit, offset int) string {
+	if limit <= 0 {
+		return ""
+	}
+	clause := fmt.Sprintf("LIMIT %d", limit)
+	if offset > 0 {
+		clause += fmt.Sprintf(" OFFSET %d", offset)
+	}
+	return clause
+}
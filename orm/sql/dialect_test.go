@@ -0,0 +1,70 @@
+package sql
+
+import "testing"
+
+func TestRebindQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		query   string
+		want    string
+	}{
+		{
+			name:    "mysql leaves placeholders untouched",
+			dialect: mysqlDialect{},
+			query:   "SELECT * FROM users WHERE id = ? AND name = ?",
+			want:    "SELECT * FROM users WHERE id = ? AND name = ?",
+		},
+		{
+			name:    "postgres numbers placeholders in order",
+			dialect: postgresDialect{},
+			query:   "SELECT * FROM users WHERE id = ? AND name = ?",
+			want:    "SELECT * FROM users WHERE id = $1 AND name = $2",
+		},
+		{
+			name:    "mssql numbers placeholders with @p prefix",
+			dialect: mssqlDialect{},
+			query:   "SELECT * FROM users WHERE id = ? AND name = ?",
+			want:    "SELECT * FROM users WHERE id = @p1 AND name = @p2",
+		},
+		{
+			name:    "placeholder inside single-quoted string literal is left alone",
+			dialect: postgresDialect{},
+			query:   "SELECT * FROM users WHERE note = 'what?' AND id = ?",
+			want:    "SELECT * FROM users WHERE note = 'what?' AND id = $1",
+		},
+		{
+			name:    "placeholder inside double-quoted identifier is left alone",
+			dialect: postgresDialect{},
+			query:   `SELECT * FROM "weird?col" WHERE id = ?`,
+			want:    `SELECT * FROM "weird?col" WHERE id = $1`,
+		},
+		{
+			name:    "placeholder inside a line comment is left alone",
+			dialect: postgresDialect{},
+			query:   "SELECT * FROM users -- what about ?\nWHERE id = ?",
+			want:    "SELECT * FROM users -- what about ?\nWHERE id = $1",
+		},
+		{
+			name:    "placeholder inside a block comment is left alone",
+			dialect: postgresDialect{},
+			query:   "SELECT * FROM users /* still ? */ WHERE id = ?",
+			want:    "SELECT * FROM users /* still ? */ WHERE id = $1",
+		},
+		{
+			name:    "unterminated block comment copies the remainder verbatim",
+			dialect: postgresDialect{},
+			query:   "SELECT * FROM users /* oops ?",
+			want:    "SELECT * FROM users /* oops ?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.dialect.Rebind(tt.query)
+			if got != tt.want {
+				t.Errorf("Rebind(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// compileNamed scans query for `:ident` tokens and rewrites them to
+// positional `?` placeholders in the order encountered, returning the
+// rewritten query and the ordered list of names to bind. String literals,
+// `::` type casts, and `:` inside `--`/`/* */` comments are left untouched.
+func compileNamed(query string) (string, []string) {
+	var b strings.Builder
+	var names []string
+
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"':
+			quote := c
+			b.WriteByte(c)
+			i++
+			for i < len(query) {
+				b.WriteByte(query[i])
+				if query[i] == quote {
+					break
+				}
+				i++
+			}
+		case c == '-' && i+1 < len(query) && query[i+1] == '-':
+			for i < len(query) && query[i] != '\n' {
+				b.WriteByte(query[i])
+				i++
+			}
+			if i < len(query) {
+				b.WriteByte(query[i])
+			}
+		case c == '/' && i+1 < len(query) && query[i+1] == '*':
+			end := strings.Index(query[i:], "*/")
+			if end == -1 {
+				b.WriteString(query[i:])
+				i = len(query)
+				break
+			}
+			b.WriteString(query[i : i+end+2])
+			i += end + 1
+		case c == ':' && i+1 < len(query) && query[i+1] == ':':
+			// `::` type cast, e.g. postgres "foo::int" - not a bind variable.
+			b.WriteString("::")
+			i++
+		case c == ':' && i+1 < len(query) && isIdentStart(query[i+1]):
+			j := i + 1
+			for j < len(query) && isIdentPart(query[j]) {
+				j++
+			}
+			names = append(names, query[i+1:j])
+			b.WriteByte('?')
+			i = j - 1
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String(), names
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+// namedArgs resolves names, in order, against arg, which must be a
+// map[string]interface{} or a struct (optionally a pointer to one) with
+// `db:"name"` tags.
+func namedArgs(names []string, arg interface{}) ([]interface{}, error) {
+	if m, ok := arg.(map[string]interface{}); ok {
+		args := make([]interface{}, len(names))
+		for i, name := range names {
+			val, ok := m[name]
+			if !ok {
+				return nil, fmt.Errorf("orm: named parameter %q has no value in the supplied map", name)
+			}
+			args[i] = val
+		}
+		return args, nil
+	}
+
+	v := reflect.ValueOf(arg)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("orm: named query argument must be a map[string]interface{} or a struct")
+	}
+
+	fieldByDBTag := map[string]int{}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		tag := f.Tag.Get("db")
+		if tag == "" {
+			tag = toSnakeCase(f.Name)
+		}
+		fieldByDBTag[tag] = i
+	}
+
+	args := make([]interface{}, len(names))
+	for i, name := range names {
+		idx, ok := fieldByDBTag[name]
+		if !ok {
+			return nil, fmt.Errorf("orm: named parameter %q has no matching struct field", name)
+		}
+		args[i] = v.Field(idx).Interface()
+	}
+	return args, nil
+}
+
+// NamedStmt is a named-parameter query compiled once and reused across
+// calls with different argument values.
+type NamedStmt struct {
+	query string // positional, `?`-only query (pre-Rebind)
+	names []string
+}
+
+// PrepareNamed compiles a query containing `:name` placeholders into a
+// reusable NamedStmt.
+func PrepareNamed(query string) *NamedStmt {
+	positional, names := compileNamed(query)
+	return &NamedStmt{query: positional, names: names}
+}
+
+// Exec runs the statement against arg (a map[string]interface{} or a
+// `db`-tagged struct) and returns the result.
+func (s *NamedStmt) Exec(ctx context.Context, arg interface{}) (sql.Result, error) {
+	args, err := namedArgs(s.names, arg)
+	if err != nil {
+		return nil, err
+	}
+	return GetDB().ExecContext(ctx, dialect.Rebind(s.query), args...)
+}
+
+// Query runs the statement against arg and returns the raw *sql.Rows.
+func (s *NamedStmt) Query(ctx context.Context, arg interface{}) (*sql.Rows, error) {
+	args, err := namedArgs(s.names, arg)
+	if err != nil {
+		return nil, err
+	}
+	return GetDB().QueryContext(ctx, dialect.Rebind(s.query), args...)
+}
+
+// Select runs the statement against arg and scans every result row into
+// dest, a pointer to a slice of structs. Field matching, type handling,
+// and per-(columns, struct type) plan caching are shared with
+// QueryBuilder.ExecuteInto via scanRowsInto.
+func (s *NamedStmt) Select(ctx context.Context, dest interface{}, arg interface{}) error {
+	args, err := namedArgs(s.names, arg)
+	if err != nil {
+		return err
+	}
+	if _, err := scanRowsInto(ctx, dialect.Rebind(s.query), args, dest); err != nil {
+		return fmt.Errorf("orm: named select: %w", err)
+	}
+	return nil
+}
+
+// NamedQuery compiles and runs query in one step, returning the raw rows.
+func NamedQuery(ctx context.Context, query string, arg interface{}) (*sql.Rows, error) {
+	return PrepareNamed(query).Query(ctx, arg)
+}
+
+// NamedExec compiles and runs query in one step.
+func NamedExec(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	return PrepareNamed(query).Exec(ctx, arg)
+}
@@ -0,0 +1,70 @@
+package sql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompileNamed(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantQuery string
+		wantNames []string
+	}{
+		{
+			name:      "single named parameter",
+			query:     "SELECT * FROM users WHERE id = :id",
+			wantQuery: "SELECT * FROM users WHERE id = ?",
+			wantNames: []string{"id"},
+		},
+		{
+			name:      "multiple named parameters in order",
+			query:     "INSERT INTO users (id, name) VALUES (:id, :name)",
+			wantQuery: "INSERT INTO users (id, name) VALUES (?, ?)",
+			wantNames: []string{"id", "name"},
+		},
+		{
+			name:      "colon inside a single-quoted string literal is left alone",
+			query:     "SELECT * FROM users WHERE note = 'ratio 1:2' AND id = :id",
+			wantQuery: "SELECT * FROM users WHERE note = 'ratio 1:2' AND id = ?",
+			wantNames: []string{"id"},
+		},
+		{
+			name:      "colon inside a double-quoted identifier is left alone",
+			query:     `SELECT * FROM users WHERE "weird:col" = :id`,
+			wantQuery: `SELECT * FROM users WHERE "weird:col" = ?`,
+			wantNames: []string{"id"},
+		},
+		{
+			name:      "double colon type cast is not a bind variable",
+			query:     "SELECT id::int FROM users WHERE id = :id",
+			wantQuery: "SELECT id::int FROM users WHERE id = ?",
+			wantNames: []string{"id"},
+		},
+		{
+			name:      "colon inside a line comment is left alone",
+			query:     "SELECT * FROM users -- note :not_a_param\nWHERE id = :id",
+			wantQuery: "SELECT * FROM users -- note :not_a_param\nWHERE id = ?",
+			wantNames: []string{"id"},
+		},
+		{
+			name:      "colon inside a block comment is left alone",
+			query:     "SELECT * FROM users /* :not_a_param */ WHERE id = :id",
+			wantQuery: "SELECT * FROM users /* :not_a_param */ WHERE id = ?",
+			wantNames: []string{"id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotQuery, gotNames := compileNamed(tt.query)
+			if gotQuery != tt.wantQuery {
+				t.Errorf("compileNamed(%q) query = %q, want %q", tt.query, gotQuery, tt.wantQuery)
+			}
+			if !reflect.DeepEqual(gotNames, tt.wantNames) {
+				t.Errorf("compileNamed(%q) names = %v, want %v", tt.query, gotNames, tt.wantNames)
+			}
+		})
+	}
+}
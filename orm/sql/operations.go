@@ -102,9 +102,40 @@ func (qb *QueryBuilder) Cursor(field string, value interface{}) *QueryBuilder {
 	return qb
 }
 
+// quoteIdent wraps ident in the active dialect's identifier-quoting
+// characters, unless it isn't a plain (optionally dotted) identifier -
+// e.g. "*" or an aggregate expression like "COUNT(*)" - in which case it
+// is returned unchanged.
+func quoteIdent(ident string) string {
+	if ident == "" || ident == "*" {
+		return ident
+	}
+	for i := 0; i < len(ident); i++ {
+		c := ident[i]
+		isIdentChar := c == '_' || c == '.' ||
+			(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+		if !isIdentChar {
+			return ident
+		}
+	}
+	if dot := strings.IndexByte(ident, '.'); dot >= 0 {
+		return dialect.Quote(ident[:dot]) + "." + dialect.Quote(ident[dot+1:])
+	}
+	return dialect.Quote(ident)
+}
+
+// quoteIdents applies quoteIdent to every element of idents.
+func quoteIdents(idents []string) []string {
+	quoted := make([]string, len(idents))
+	for i, ident := range idents {
+		quoted[i] = quoteIdent(ident)
+	}
+	return quoted
+}
+
 // Build constructs the final SQL query string.
 func (qb *QueryBuilder) Build() (string, []interface{}) {
-	query := fmt.Sprintf("SELECT %s FROM %s ", strings.Join(qb.Columns, ", "), qb.Table)
+	query := fmt.Sprintf("SELECT %s FROM %s ", strings.Join(quoteIdents(qb.Columns), ", "), quoteIdent(qb.Table))
 
 	// Handle WHERE conditions (if any)
 	if len(qb.WhereConditions) > 0 {
@@ -134,7 +165,7 @@ func (qb *QueryBuilder) Build() (string, []interface{}) {
 					for i := range vals {
 						placeholders[i] = "?"
 					}
-					clause = fmt.Sprintf("%s %s (%s)", field, OperatorIn, strings.Join(placeholders, ", "))
+					clause = fmt.Sprintf("%s %s (%s)", quoteIdent(field), OperatorIn, strings.Join(placeholders, ", "))
 					qb.Parameters = append(qb.Parameters, vals...)
 				case OperatorNotIn:
 					// For NOT IN condition, the value should be a slice
@@ -143,19 +174,19 @@ func (qb *QueryBuilder) Build() (string, []interface{}) {
 					for i := range vals {
 						placeholders[i] = "?"
 					}
-					clause = fmt.Sprintf("%s %s (%s)", field, OperatorNotIn, strings.Join(placeholders, ", "))
+					clause = fmt.Sprintf("%s %s (%s)", quoteIdent(field), OperatorNotIn, strings.Join(placeholders, ", "))
 					qb.Parameters = append(qb.Parameters, vals...)
 				case OperatorBetween:
 					// BETWEEN expects two values
 					vals := value.([]interface{})
-					clause = fmt.Sprintf("%s %s ? AND ?", field, OperatorBetween)
+					clause = fmt.Sprintf("%s %s ? AND ?", quoteIdent(field), OperatorBetween)
 					qb.Parameters = append(qb.Parameters, vals...)
 				case OperatorLike:
-					clause = fmt.Sprintf("%s %s ?", field, OperatorLike)
+					clause = fmt.Sprintf("%s %s ?", quoteIdent(field), OperatorLike)
 					qb.Parameters = append(qb.Parameters, value)
 				default:
 					// Default to equality operator
-					clause = fmt.Sprintf("%s %s ?", field, operator)
+					clause = fmt.Sprintf("%s %s ?", quoteIdent(field), operator)
 					qb.Parameters = append(qb.Parameters, value)
 				}
 
@@ -171,10 +202,10 @@ func (qb *QueryBuilder) Build() (string, []interface{}) {
 		for _, field := range qb.OrderByFields {
 			switch v := field.(type) {
 			case string:
-				orderByClauses = append(orderByClauses, v)
+				orderByClauses = append(orderByClauses, quoteIdent(v))
 			case map[string]string:
 				for f, direction := range v {
-					orderByClauses = append(orderByClauses, fmt.Sprintf("%s %s", f, direction))
+					orderByClauses = append(orderByClauses, fmt.Sprintf("%s %s", quoteIdent(f), direction))
 				}
 			}
 		}
@@ -183,16 +214,20 @@ func (qb *QueryBuilder) Build() (string, []interface{}) {
 
 	// Handle Cursor Pagination (if any)
 	if qb.CursorField != "" && qb.CursorValue != nil {
-		query += fmt.Sprintf(" AND %s > ? ", qb.CursorField)
+		query += fmt.Sprintf(" AND %s > ? ", quoteIdent(qb.CursorField))
 		qb.Parameters = append(qb.Parameters, qb.CursorValue)
 	}
 
-	// Handle LIMIT (if any)
+	// Handle LIMIT/OFFSET (if any), rendered per the active dialect - mysql,
+	// postgres, and sqlite3 all use "LIMIT n [OFFSET m]", but mssql has no
+	// LIMIT keyword and needs "OFFSET ... FETCH NEXT ... ROWS ONLY" instead.
 	if qb.LimitCount > 0 {
-		query += fmt.Sprintf(" LIMIT %d", qb.LimitCount)
+		if clause := dialect.LimitOffset(qb.LimitCount, 0); clause != "" {
+			query += " " + clause
+		}
 	}
 
-	return query, qb.Parameters
+	return dialect.Rebind(query), qb.Parameters
 }
 
 // Execute runs the query and returns the results as []map[string]interface{}
@@ -261,10 +296,10 @@ func Create(tableName string, data map[string]interface{}) error {
 	for i := range placeholders {
 		placeholders[i] = "?"
 	}
-	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", tableName, strings.Join(columns, ", "), strings.Join(placeholders, ", "))
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", quoteIdent(tableName), strings.Join(quoteIdents(columns), ", "), strings.Join(placeholders, ", "))
 
 	// Execute the query
-	_, err := db.Exec(query, values...)
+	_, err := db.Exec(dialect.Rebind(query), values...)
 	if err != nil {
 		return fmt.Errorf("failed to insert record: %w", err)
 	}
@@ -294,9 +329,9 @@ func CreateBulk(tableName string, data []map[string]interface{}) error {
 		placeholders = append(placeholders, fmt.Sprintf("(%s)", strings.Join(placeholdersRow, ", ")))
 	}
 
-	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", tableName, strings.Join(columns, ", "), strings.Join(placeholders, ", "))
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", quoteIdent(tableName), strings.Join(quoteIdents(columns), ", "), strings.Join(placeholders, ", "))
 
-	_, err := db.Exec(query, values...)
+	_, err := db.Exec(dialect.Rebind(query), values...)
 	if err != nil {
 		return fmt.Errorf("failed to insert bulk records: %w", err)
 	}
@@ -310,24 +345,24 @@ func Update(tableName string, data map[string]interface{}, where map[string]inte
 	setClauses := []string{}
 	args := []interface{}{}
 	for col, val := range data {
-		setClauses = append(setClauses, fmt.Sprintf("%s = ?", col))
+		setClauses = append(setClauses, fmt.Sprintf("%s = ?", quoteIdent(col)))
 		args = append(args, val)
 	}
 
 	// Build WHERE clause
 	whereClauses := []string{}
 	for col, val := range where {
-		whereClauses = append(whereClauses, fmt.Sprintf("%s = ?", col))
+		whereClauses = append(whereClauses, fmt.Sprintf("%s = ?", quoteIdent(col)))
 		args = append(args, val)
 	}
 
 	// Combine everything into the final SQL query
 	setStr := strings.Join(setClauses, ", ")
 	whereStr := "WHERE " + strings.Join(whereClauses, " AND ")
-	query := fmt.Sprintf("UPDATE %s SET %s %s", tableName, setStr, whereStr)
+	query := fmt.Sprintf("UPDATE %s SET %s %s", quoteIdent(tableName), setStr, whereStr)
 
 	// Execute the query
-	_, err := db.Exec(query, args...)
+	_, err := db.Exec(dialect.Rebind(query), args...)
 	if err != nil {
 		return fmt.Errorf("failed to update record: %w", err)
 	}
@@ -341,14 +376,14 @@ func Delete(tableName string, where map[string]interface{}) error {
 	whereClauses := []string{}
 	args := []interface{}{}
 	for col, val := range where {
-		whereClauses = append(whereClauses, fmt.Sprintf("%s = ?", col))
+		whereClauses = append(whereClauses, fmt.Sprintf("%s = ?", quoteIdent(col)))
 		args = append(args, val)
 	}
 
 	whereStr := "WHERE " + strings.Join(whereClauses, " AND ")
-	query := fmt.Sprintf("DELETE FROM %s %s", tableName, whereStr)
+	query := fmt.Sprintf("DELETE FROM %s %s", quoteIdent(tableName), whereStr)
 
-	_, err := db.Exec(query, args...)
+	_, err := db.Exec(dialect.Rebind(query), args...)
 	if err != nil {
 		return fmt.Errorf("failed to delete record: %w", err)
 	}
@@ -362,18 +397,18 @@ func SoftDelete(tableName string, where map[string]interface{}) error {
 	whereClauses := []string{}
 	args := []interface{}{}
 	for col, val := range where {
-		whereClauses = append(whereClauses, fmt.Sprintf("%s = ?", col))
+		whereClauses = append(whereClauses, fmt.Sprintf("%s = ?", quoteIdent(col)))
 		args = append(args, val)
 	}
 
 	// Add the current timestamp to the update data
-	setStr := "deleted_at = ?"
+	setStr := quoteIdent("deleted_at") + " = ?"
 	args = append(args, time.Now())
 
 	whereStr := "WHERE " + strings.Join(whereClauses, " AND ")
-	query := fmt.Sprintf("UPDATE %s SET %s %s", tableName, setStr, whereStr)
+	query := fmt.Sprintf("UPDATE %s SET %s %s", quoteIdent(tableName), setStr, whereStr)
 
-	_, err := db.Exec(query, args...)
+	_, err := db.Exec(dialect.Rebind(query), args...)
 	if err != nil {
 		return fmt.Errorf("failed to perform soft delete: %w", err)
 	}
@@ -462,6 +497,6 @@ func (qb *QueryBuilder) BuildInsertQuery(data map[string]interface{}) (string, [
 	}
 
 	// Build the INSERT query
-	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", qb.Table, strings.Join(columns, ", "), strings.Join(placeholders, ", "))
-	return query, values
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", quoteIdent(qb.Table), strings.Join(quoteIdents(columns), ", "), strings.Join(placeholders, ", "))
+	return dialect.Rebind(query), values
 }
@@ -0,0 +1,528 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// fieldInfo describes a single struct field mapped to a database column.
+type fieldInfo struct {
+	Name     string // Go struct field name
+	Column   string // Database column name
+	Index    int    // Index into the struct's fields
+	PK       bool   // Primary key column
+	Auto     bool   // Auto-incrementing / DB-generated value
+	Null     bool   // Column accepts NULL
+	Size     int    // Size constraint, e.g. size(255)
+	RelType  string // "", "one", "fk", "many", "m2m"
+	RelModel string // Registered name of the related model, for relation fields
+}
+
+// modelInfo describes a registered model's table and field mappings.
+type modelInfo struct {
+	Name    string // Registered model name (defaults to the struct name)
+	Table   string
+	Type    reflect.Type
+	Fields  []*fieldInfo
+	PKField *fieldInfo
+}
+
+// modelCache holds every model registered via RegisterModel, keyed by name.
+var modelCache = map[string]*modelInfo{}
+
+// RegisterModel scans ptr (a pointer to a struct) for `orm` struct tags and
+// registers it so the Ormer can build queries for it. Call this once per
+// model, typically from an init() function.
+func RegisterModel(table string, ptr interface{}) error {
+	t := reflect.TypeOf(ptr)
+	if t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("RegisterModel: ptr must be a pointer to a struct")
+	}
+	t = t.Elem()
+
+	mi := &modelInfo{
+		Name:  t.Name(),
+		Table: table,
+		Type:  t,
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			// Unexported field, skip.
+			continue
+		}
+
+		fi := parseFieldTag(f, i)
+		mi.Fields = append(mi.Fields, fi)
+		if fi.PK {
+			mi.PKField = fi
+		}
+	}
+
+	if mi.PKField == nil && len(mi.Fields) > 0 {
+		// Fall back to the first field, mirroring the common "Id" convention.
+		mi.PKField = mi.Fields[0]
+		mi.PKField.PK = true
+	}
+
+	modelCache[mi.Name] = mi
+	return nil
+}
+
+// parseFieldTag turns a struct field's `orm:"..."` tag into a fieldInfo.
+// Supported tag options: pk, auto, null, column(name), size(n), rel(one),
+// rel(fk), rel(m2m), reverse(many).
+func parseFieldTag(f reflect.StructField, index int) *fieldInfo {
+	fi := &fieldInfo{
+		Name:   f.Name,
+		Column: toSnakeCase(f.Name),
+		Index:  index,
+	}
+
+	tag := f.Tag.Get("orm")
+	if tag == "" {
+		return fi
+	}
+
+	for _, opt := range strings.Split(tag, ";") {
+		opt = strings.TrimSpace(opt)
+		switch {
+		case opt == "pk":
+			fi.PK = true
+		case opt == "auto":
+			fi.Auto = true
+		case opt == "null":
+			fi.Null = true
+		case strings.HasPrefix(opt, "column("):
+			fi.Column = extractArg(opt)
+		case strings.HasPrefix(opt, "size("):
+			fmt.Sscanf(extractArg(opt), "%d", &fi.Size)
+		case strings.HasPrefix(opt, "rel("):
+			fi.RelType = extractArg(opt)
+			fi.RelModel = relatedTypeName(f.Type)
+		case strings.HasPrefix(opt, "reverse("):
+			fi.RelType = "reverse_" + extractArg(opt)
+			fi.RelModel = relatedTypeName(f.Type)
+		}
+	}
+
+	return fi
+}
+
+// extractArg pulls the value out of a tag option like "column(id)" -> "id".
+func extractArg(opt string) string {
+	start := strings.IndexByte(opt, '(')
+	end := strings.LastIndexByte(opt, ')')
+	if start == -1 || end == -1 || end <= start {
+		return ""
+	}
+	return opt[start+1 : end]
+}
+
+// relatedTypeName resolves the registered model name a relation field
+// points at, unwrapping the slice and pointer layers relation fields are
+// conventionally declared with: `Author *Author` (rel(fk)), `Tags []*Tag`
+// (rel(m2m)), and `Posts []*Post` (reverse(many)) all resolve to "Author",
+// "Tag", and "Post" respectively.
+func relatedTypeName(t reflect.Type) string {
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// toSnakeCase converts a Go exported field name (e.g. "UserName") to the
+// conventional snake_case column name ("user_name").
+func toSnakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToLower(b.String())
+}
+
+// Ormer is the model-based counterpart to the map-based helpers in this
+// package: it reads and writes Go structs directly instead of requiring
+// callers to hand-build column/value maps.
+type Ormer interface {
+	Insert(ptr interface{}) (int64, error)
+	Read(ptr interface{}, cols ...string) error
+	Update(ptr interface{}, cols ...string) error
+	Delete(ptr interface{}) error
+	ReadOrCreate(ptr interface{}, cols ...string) (bool, error)
+	LoadRelated(ptr interface{}, name string) error
+}
+
+// ormObject is the default Ormer implementation backed by the package-level
+// *sql.DB returned by GetDB.
+type ormObject struct {
+	ctx context.Context
+}
+
+// NewOrm returns an Ormer bound to context.Background(). Use NewOrmWithCtx
+// to bind request-scoped deadlines/cancellation instead.
+func NewOrm() Ormer {
+	return NewOrmWithCtx(context.Background())
+}
+
+// NewOrmWithCtx returns an Ormer that threads ctx through every query.
+func NewOrmWithCtx(ctx context.Context) Ormer {
+	return &ormObject{ctx: ctx}
+}
+
+// modelInfoForPtr resolves the modelInfo and reflect.Value for ptr, which
+// must be a non-nil pointer to a registered struct.
+func modelInfoForPtr(ptr interface{}) (*modelInfo, reflect.Value, error) {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil, reflect.Value{}, fmt.Errorf("orm: ptr must be a non-nil pointer to a struct")
+	}
+	elem := v.Elem()
+	mi, ok := modelCache[elem.Type().Name()]
+	if !ok {
+		return nil, reflect.Value{}, fmt.Errorf("orm: model %s is not registered, call RegisterModel first", elem.Type().Name())
+	}
+	return mi, elem, nil
+}
+
+// getExistPk collects the primary key column and its current value off ptr,
+// returning ok=false when the PK field is still its zero value (i.e. the
+// record has not been persisted yet).
+func getExistPk(mi *modelInfo, elem reflect.Value) (column string, value interface{}, ok bool) {
+	pkVal := elem.Field(mi.PKField.Index)
+	if pkVal.IsZero() {
+		return mi.PKField.Column, nil, false
+	}
+	return mi.PKField.Column, pkVal.Interface(), true
+}
+
+// Insert writes ptr's fields as a new row and, when the primary key is
+// auto-generated, reads the new id back into ptr.
+func (o *ormObject) Insert(ptr interface{}) (int64, error) {
+	mi, elem, err := modelInfoForPtr(ptr)
+	if err != nil {
+		return 0, err
+	}
+
+	data := map[string]interface{}{}
+	for _, fi := range mi.Fields {
+		if fi.PK && fi.Auto {
+			// Let the database assign the value.
+			continue
+		}
+		data[fi.Column] = elem.Field(fi.Index).Interface()
+	}
+
+	if mi.PKField != nil && mi.PKField.Auto {
+		id, err := insertReturningID(o.ctx, mi.Table, data, mi.PKField.Column)
+		if err != nil {
+			return 0, fmt.Errorf("orm: insert %s: %w", mi.Name, err)
+		}
+		elem.Field(mi.PKField.Index).Set(reflect.ValueOf(id).Convert(elem.Field(mi.PKField.Index).Type()))
+		return id, nil
+	}
+
+	if err := Create(mi.Table, data); err != nil {
+		return 0, fmt.Errorf("orm: insert %s: %w", mi.Name, err)
+	}
+
+	_, pk, _ := getExistPk(mi, elem)
+	if id, ok := pk.(int64); ok {
+		return id, nil
+	}
+	return 0, nil
+}
+
+// Read loads the row matching ptr's primary key into ptr, optionally
+// restricting which columns are refreshed.
+func (o *ormObject) Read(ptr interface{}, cols ...string) error {
+	mi, elem, err := modelInfoForPtr(ptr)
+	if err != nil {
+		return err
+	}
+
+	pkCol, pkVal, ok := getExistPk(mi, elem)
+	if !ok {
+		return fmt.Errorf("orm: read %s: primary key is not set", mi.Name)
+	}
+
+	qb := NewQueryBuilder(mi.Table, nil)
+	if len(cols) > 0 {
+		qb.Select(cols...)
+	} else {
+		qb.Select(columnsOf(mi)...)
+	}
+	qb.Where([]map[string]interface{}{{pkCol: pkVal}}, nil)
+	qb.Limit(1)
+
+	found, err := scanOneInto(o.ctx, qb, elem)
+	if err != nil {
+		return fmt.Errorf("orm: read %s: %w", mi.Name, err)
+	}
+	if !found {
+		return fmt.Errorf("orm: read %s: no row found for %s=%v", mi.Name, pkCol, pkVal)
+	}
+	return nil
+}
+
+// Update writes ptr's fields back to its row, optionally restricting which
+// columns are written.
+func (o *ormObject) Update(ptr interface{}, cols ...string) error {
+	mi, elem, err := modelInfoForPtr(ptr)
+	if err != nil {
+		return err
+	}
+
+	pkCol, pkVal, ok := getExistPk(mi, elem)
+	if !ok {
+		return fmt.Errorf("orm: update %s: primary key is not set", mi.Name)
+	}
+
+	include := map[string]bool{}
+	for _, c := range cols {
+		include[c] = true
+	}
+
+	data := map[string]interface{}{}
+	for _, fi := range mi.Fields {
+		if fi.PK {
+			continue
+		}
+		if len(include) > 0 && !include[fi.Column] {
+			continue
+		}
+		data[fi.Column] = elem.Field(fi.Index).Interface()
+	}
+
+	if err := Update(mi.Table, data, map[string]interface{}{pkCol: pkVal}); err != nil {
+		return fmt.Errorf("orm: update %s: %w", mi.Name, err)
+	}
+	return nil
+}
+
+// Delete removes ptr's row by primary key.
+func (o *ormObject) Delete(ptr interface{}) error {
+	mi, elem, err := modelInfoForPtr(ptr)
+	if err != nil {
+		return err
+	}
+
+	pkCol, pkVal, ok := getExistPk(mi, elem)
+	if !ok {
+		return fmt.Errorf("orm: delete %s: primary key is not set", mi.Name)
+	}
+
+	if err := Delete(mi.Table, map[string]interface{}{pkCol: pkVal}); err != nil {
+		return fmt.Errorf("orm: delete %s: %w", mi.Name, err)
+	}
+	return nil
+}
+
+// ReadOrCreate looks up a row whose cols columns equal ptr's current values
+// for those fields and loads it into ptr; if no row matches, it inserts ptr
+// instead. The bool return reports whether the row was created.
+func (o *ormObject) ReadOrCreate(ptr interface{}, cols ...string) (bool, error) {
+	mi, elem, err := modelInfoForPtr(ptr)
+	if err != nil {
+		return false, err
+	}
+
+	conditions := map[string]interface{}{}
+	for _, col := range cols {
+		for _, fi := range mi.Fields {
+			if fi.Column == col {
+				conditions[col] = elem.Field(fi.Index).Interface()
+			}
+		}
+	}
+
+	qb := NewQueryBuilder(mi.Table, nil).Select(columnsOf(mi)...)
+	qb.Where([]map[string]interface{}{conditions}, nil)
+	qb.Limit(1)
+
+	found, err := scanOneInto(o.ctx, qb, elem)
+	if err != nil {
+		return false, fmt.Errorf("orm: read_or_create %s: %w", mi.Name, err)
+	}
+	if found {
+		return false, nil
+	}
+
+	if _, err := o.Insert(ptr); err != nil {
+		return false, fmt.Errorf("orm: read_or_create %s: %w", mi.Name, err)
+	}
+	return true, nil
+}
+
+// LoadRelated populates the named relation field on ptr (e.g. a `rel(fk)` or
+// `reverse(many)` field) by querying the related model's table. A `rel(m2m)`
+// field is not loaded: it takes the rel(one)/rel(fk) path, which looks for a
+// `<name>_id` column on ptr's own model.
+func (o *ormObject) LoadRelated(ptr interface{}, name string) error {
+	mi, elem, err := modelInfoForPtr(ptr)
+	if err != nil {
+		return err
+	}
+
+	var fi *fieldInfo
+	for _, f := range mi.Fields {
+		if f.Name == name {
+			fi = f
+			break
+		}
+	}
+	if fi == nil || fi.RelType == "" {
+		return fmt.Errorf("orm: %s has no relation field %q", mi.Name, name)
+	}
+
+	related, ok := modelCache[fi.RelModel]
+	if !ok {
+		return fmt.Errorf("orm: related model %q is not registered", fi.RelModel)
+	}
+
+	_, pkVal, ok := getExistPk(mi, elem)
+	if !ok {
+		return fmt.Errorf("orm: load_related %s.%s: primary key is not set", mi.Name, name)
+	}
+
+	switch {
+	case strings.HasPrefix(fi.RelType, "reverse"):
+		// One-to-many: the related table holds the FK back to this model.
+		fkCol := toSnakeCase(mi.Name) + "_id"
+		qb := NewQueryBuilder(related.Table, nil).Select(columnsOf(related)...)
+		qb.Where([]map[string]interface{}{{fkCol: pkVal}}, nil)
+		if err := scanAllInto(o.ctx, qb, elem.Field(fi.Index)); err != nil {
+			return fmt.Errorf("orm: load_related %s.%s: %w", mi.Name, name, err)
+		}
+	default:
+		// one/fk: this model holds the FK pointing at the related row.
+		fkCol := toSnakeCase(name) + "_id"
+		var fkVal interface{}
+		for _, f := range mi.Fields {
+			if f.Column == fkCol {
+				fkVal = elem.Field(f.Index).Interface()
+				break
+			}
+		}
+		qb := NewQueryBuilder(related.Table, nil).Select(columnsOf(related)...)
+		qb.Where([]map[string]interface{}{{related.PKField.Column: fkVal}}, nil)
+		qb.Limit(1)
+		if _, err := scanOneInto(o.ctx, qb, elem.Field(fi.Index)); err != nil {
+			return fmt.Errorf("orm: load_related %s.%s: %w", mi.Name, name, err)
+		}
+	}
+
+	return nil
+}
+
+// columnsOf returns mi's column names in field order.
+func columnsOf(mi *modelInfo) []string {
+	cols := make([]string, len(mi.Fields))
+	for i, fi := range mi.Fields {
+		cols[i] = fi.Column
+	}
+	return cols
+}
+
+// scanOneInto runs qb and copies the first result row's columns onto dest,
+// a struct value (not a slice). It reports whether a row was found.
+func scanOneInto(ctx context.Context, qb *QueryBuilder, dest reflect.Value) (bool, error) {
+	rows, err := qb.Execute(ctx)
+	if err != nil {
+		return false, err
+	}
+	if len(rows) == 0 {
+		return false, nil
+	}
+	assignRow(rows[0], dest)
+	return true, nil
+}
+
+// scanAllInto runs qb and appends every result row onto dest, a slice field.
+func scanAllInto(ctx context.Context, qb *QueryBuilder, dest reflect.Value) error {
+	rows, err := qb.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	elemType := dest.Type().Elem()
+	slice := reflect.MakeSlice(dest.Type(), 0, len(rows))
+	for _, row := range rows {
+		item := reflect.New(elemType).Elem()
+		assignRow(row, item)
+		slice = reflect.Append(slice, item)
+	}
+	dest.Set(slice)
+	return nil
+}
+
+// assignRow copies a map[column]value row onto dest's matching fields,
+// matched by registered column name.
+func assignRow(row map[string]interface{}, dest reflect.Value) {
+	if dest.Kind() == reflect.Ptr {
+		if dest.IsNil() {
+			dest.Set(reflect.New(dest.Type().Elem()))
+		}
+		dest = dest.Elem()
+	}
+	mi, ok := modelCache[dest.Type().Name()]
+	if !ok {
+		return
+	}
+	for _, fi := range mi.Fields {
+		val, ok := row[fi.Column]
+		if !ok || val == nil {
+			continue
+		}
+		field := dest.Field(fi.Index)
+		v := reflect.ValueOf(val)
+		if v.Type().ConvertibleTo(field.Type()) {
+			field.Set(v.Convert(field.Type()))
+		}
+	}
+}
+
+// insertReturningID inserts data into table and reports the value assigned
+// to its auto-generated primary key pkColumn. On dialects that support
+// RETURNING (postgres), the id is read back from the INSERT itself;
+// otherwise it falls back to the driver's LastInsertId.
+func insertReturningID(ctx context.Context, table string, data map[string]interface{}, pkColumn string) (int64, error) {
+	columns := make([]string, 0, len(data))
+	placeholders := make([]string, 0, len(data))
+	values := make([]interface{}, 0, len(data))
+	for col, val := range data {
+		columns = append(columns, col)
+		placeholders = append(placeholders, "?")
+		values = append(values, val)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", quoteIdent(table), strings.Join(quoteIdents(columns), ", "), strings.Join(placeholders, ", "))
+
+	if returning := dialect.Returning(quoteIdent(pkColumn)); returning != "" {
+		query = dialect.Rebind(query + " " + returning)
+		var id int64
+		if err := GetDB().QueryRowContext(ctx, query, values...).Scan(&id); err != nil {
+			return 0, fmt.Errorf("failed to insert record: %w", err)
+		}
+		return id, nil
+	}
+
+	result, err := GetDB().ExecContext(ctx, dialect.Rebind(query), values...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert record: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read last insert id: %w", err)
+	}
+	return id, nil
+}
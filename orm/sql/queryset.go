@@ -0,0 +1,336 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// filterOperators is the set of Django/Beego-style operator suffixes
+// recognised at the end of a filter expression key.
+var filterOperators = map[string]bool{
+	"exact":      true,
+	"iexact":     true,
+	"contains":   true,
+	"icontains":  true,
+	"startswith": true,
+	"endswith":   true,
+	"gt":         true,
+	"gte":        true,
+	"lt":         true,
+	"lte":        true,
+	"in":         true,
+	"between":    true,
+	"isnull":     true,
+}
+
+// QuerySet is a fluent, model-aware query builder that understands
+// double-underscore filter expressions (e.g. "author__profile__age__gte")
+// and auto-joins across relation fields registered via RegisterModel.
+type QuerySet struct {
+	mi         *modelInfo
+	qb         *QueryBuilder
+	joins      []string
+	joinAlias  map[string]string // relation path (joined by ".") -> table alias
+	aliasCount int
+	err        error
+}
+
+// NewQuerySet starts a QuerySet for the given registered model name.
+func NewQuerySet(modelName string) *QuerySet {
+	mi, ok := modelCache[modelName]
+	if !ok {
+		return &QuerySet{err: fmt.Errorf("orm: model %q is not registered", modelName)}
+	}
+	return &QuerySet{
+		mi:        mi,
+		qb:        NewQueryBuilder(mi.Table, nil).Select(columnsOf(mi)...),
+		joinAlias: map[string]string{},
+	}
+}
+
+// Filter adds AND-ed WHERE conditions from a map of expression keys to
+// values, e.g. Filter(map[string]interface{}{"name__icontains": "bob"}).
+func (qs *QuerySet) Filter(filters map[string]interface{}) *QuerySet {
+	return qs.addConditions(filters, false)
+}
+
+// Exclude adds AND-ed, negated WHERE conditions.
+func (qs *QuerySet) Exclude(filters map[string]interface{}) *QuerySet {
+	return qs.addConditions(filters, true)
+}
+
+// RelatedSel eagerly joins the named relation so its columns are available
+// for filtering (mirrors Beego's RelatedSel).
+func (qs *QuerySet) RelatedSel(name string) *QuerySet {
+	if qs.err != nil {
+		return qs
+	}
+	if _, err := qs.resolvePath([]string{name}); err != nil {
+		qs.err = err
+	}
+	return qs
+}
+
+// addConditions parses each filter key into a relation path, column and
+// operator, resolves any joins required to reach the column, and appends
+// the resulting SQL clause.
+func (qs *QuerySet) addConditions(filters map[string]interface{}, negate bool) *QuerySet {
+	if qs.err != nil {
+		return qs
+	}
+
+	for key, value := range filters {
+		parts := strings.Split(key, "__")
+
+		operator := "exact"
+		if len(parts) > 1 && filterOperators[parts[len(parts)-1]] {
+			operator = parts[len(parts)-1]
+			parts = parts[:len(parts)-1]
+		}
+
+		if len(parts) == 0 {
+			qs.err = fmt.Errorf("orm: invalid filter key %q", key)
+			return qs
+		}
+
+		relationPath, column := parts[:len(parts)-1], parts[len(parts)-1]
+
+		var qualifiedColumn string
+		if len(relationPath) > 0 {
+			alias, err := qs.resolvePath(relationPath)
+			if err != nil {
+				qs.err = err
+				return qs
+			}
+			qualifiedColumn = dialect.Quote(alias) + "." + dialect.Quote(column)
+		} else {
+			qualifiedColumn = dialect.Quote(qs.mi.Table) + "." + dialect.Quote(column)
+		}
+
+		clause, args := buildOperatorClause(qualifiedColumn, operator, value)
+		if negate {
+			clause = "NOT (" + clause + ")"
+		}
+		qs.qb.WhereConditions = append(qs.qb.WhereConditions, map[string]interface{}{clause: nil})
+		qs.qb.Parameters = append(qs.qb.Parameters, args...)
+	}
+
+	return qs
+}
+
+// resolvePath walks relationPath from qs.mi, emitting a JOIN for each hop
+// that hasn't already been joined, and returns the alias for the final
+// related table.
+func (qs *QuerySet) resolvePath(relationPath []string) (string, error) {
+	current := qs.mi
+	currentTable := qs.mi.Table
+	pathKey := ""
+
+	for _, step := range relationPath {
+		pathKey += "." + step
+
+		if alias, ok := qs.joinAlias[pathKey]; ok {
+			current = modelCache[relatedModelName(current, step)]
+			currentTable = alias
+			continue
+		}
+
+		var fi *fieldInfo
+		for _, f := range current.Fields {
+			if strings.EqualFold(f.Name, step) && f.RelType != "" {
+				fi = f
+				break
+			}
+		}
+		if fi == nil {
+			return "", fmt.Errorf("orm: %s has no relation field %q", current.Name, step)
+		}
+
+		related, ok := modelCache[fi.RelModel]
+		if !ok {
+			return "", fmt.Errorf("orm: related model %q is not registered", fi.RelModel)
+		}
+
+		alias := fmt.Sprintf("T%d", qs.aliasCount)
+		qs.aliasCount++
+
+		var on string
+		if strings.HasPrefix(fi.RelType, "reverse") {
+			fkCol := toSnakeCase(current.Name) + "_id"
+			on = fmt.Sprintf("%s.%s = %s.%s", dialect.Quote(currentTable), dialect.Quote(current.PKField.Column), dialect.Quote(alias), dialect.Quote(fkCol))
+		} else {
+			fkCol := fi.Column
+			if fkCol == toSnakeCase(fi.Name) {
+				fkCol = toSnakeCase(fi.Name) + "_id"
+			}
+			on = fmt.Sprintf("%s.%s = %s.%s", dialect.Quote(currentTable), dialect.Quote(fkCol), dialect.Quote(alias), dialect.Quote(related.PKField.Column))
+		}
+
+		// LEFT JOIN so filtering or selecting through an optional relation
+		// (e.g. a post with no author) doesn't silently drop the row.
+		qs.joins = append(qs.joins, fmt.Sprintf("LEFT JOIN %s AS %s ON %s", dialect.Quote(related.Table), dialect.Quote(alias), on))
+		qs.joinAlias[pathKey] = alias
+		current = related
+		currentTable = alias
+	}
+
+	return currentTable, nil
+}
+
+// relatedModelName looks up the related model name reachable from mi via
+// the field named step, used to keep resolvePath's cache hits on the right
+// model when a path prefix was already joined.
+func relatedModelName(mi *modelInfo, step string) string {
+	for _, f := range mi.Fields {
+		if strings.EqualFold(f.Name, step) && f.RelType != "" {
+			return f.RelModel
+		}
+	}
+	return ""
+}
+
+// buildOperatorClause renders the SQL fragment and bind args for a single
+// (column, operator, value) filter expression.
+func buildOperatorClause(column, operator string, value interface{}) (string, []interface{}) {
+	switch operator {
+	case "iexact":
+		return fmt.Sprintf("LOWER(%s) = LOWER(?)", column), []interface{}{value}
+	case "contains":
+		return fmt.Sprintf("%s LIKE ?", column), []interface{}{fmt.Sprintf("%%%v%%", value)}
+	case "icontains":
+		return fmt.Sprintf("LOWER(%s) LIKE LOWER(?)", column), []interface{}{fmt.Sprintf("%%%v%%", value)}
+	case "startswith":
+		return fmt.Sprintf("%s LIKE ?", column), []interface{}{fmt.Sprintf("%v%%", value)}
+	case "endswith":
+		return fmt.Sprintf("%s LIKE ?", column), []interface{}{fmt.Sprintf("%%%v", value)}
+	case "gt":
+		return fmt.Sprintf("%s > ?", column), []interface{}{value}
+	case "gte":
+		return fmt.Sprintf("%s >= ?", column), []interface{}{value}
+	case "lt":
+		return fmt.Sprintf("%s < ?", column), []interface{}{value}
+	case "lte":
+		return fmt.Sprintf("%s <= ?", column), []interface{}{value}
+	case "in":
+		vals, _ := value.([]interface{})
+		placeholders := make([]string, len(vals))
+		for i := range placeholders {
+			placeholders[i] = "?"
+		}
+		return fmt.Sprintf("%s IN (%s)", column, strings.Join(placeholders, ", ")), vals
+	case "between":
+		vals, _ := value.([]interface{})
+		return fmt.Sprintf("%s BETWEEN ? AND ?", column), vals
+	case "isnull":
+		if b, _ := value.(bool); b {
+			return fmt.Sprintf("%s IS NULL", column), nil
+		}
+		return fmt.Sprintf("%s IS NOT NULL", column), nil
+	default: // exact
+		return fmt.Sprintf("%s = ?", column), []interface{}{value}
+	}
+}
+
+// buildQuery renders the full SELECT with its joins and WHERE clause.
+func (qs *QuerySet) buildQuery() (string, []interface{}, error) {
+	if qs.err != nil {
+		return "", nil, qs.err
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(prefixColumns(qs.mi), ", "), dialect.Quote(qs.mi.Table))
+	for _, join := range qs.joins {
+		query += " " + join
+	}
+
+	var whereClauses []string
+	for _, cond := range qs.qb.WhereConditions {
+		for clause := range cond {
+			whereClauses = append(whereClauses, clause)
+		}
+	}
+	if len(whereClauses) > 0 {
+		query += " WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	return query, qs.qb.Parameters, nil
+}
+
+// prefixColumns qualifies mi's columns with its table name so they don't
+// collide with joined tables' columns of the same name.
+func prefixColumns(mi *modelInfo) []string {
+	cols := make([]string, len(mi.Fields))
+	for i, fi := range mi.Fields {
+		cols[i] = dialect.Quote(mi.Table) + "." + dialect.Quote(fi.Column)
+	}
+	return cols
+}
+
+// All executes the QuerySet and scans every matching row into dst, a
+// pointer to a slice of the QuerySet's model struct.
+func (qs *QuerySet) All(ctx context.Context, dst interface{}) error {
+	query, args, err := qs.buildQuery()
+	if err != nil {
+		return err
+	}
+
+	rows, err := GetDB().QueryContext(ctx, dialect.Rebind(query), args...)
+	if err != nil {
+		return fmt.Errorf("orm: queryset execute: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			fmt.Printf("Failed to close rows: %v\n", cerr)
+		}
+	}()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return fmt.Errorf("orm: queryset columns: %w", err)
+	}
+
+	var results []map[string]interface{}
+	for rows.Next() {
+		raw := make([]interface{}, len(columns))
+		ptrs := make([]interface{}, len(columns))
+		for i := range raw {
+			ptrs[i] = &raw[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return fmt.Errorf("orm: queryset scan: %w", err)
+		}
+		row := make(map[string]interface{})
+		for i, col := range columns {
+			row[strings.TrimPrefix(col, qs.mi.Table+".")] = raw[i]
+		}
+		results = append(results, row)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("orm: queryset iteration: %w", err)
+	}
+
+	return scanRowsIntoSlice(results, dst)
+}
+
+// scanRowsIntoSlice populates dst, a pointer to a slice of structs, from
+// rows keyed by column name.
+func scanRowsIntoSlice(rows []map[string]interface{}, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("orm: All requires a pointer to a slice")
+	}
+
+	sliceVal := v.Elem()
+	elemType := sliceVal.Type().Elem()
+	slice := reflect.MakeSlice(sliceVal.Type(), 0, len(rows))
+
+	for _, row := range rows {
+		item := reflect.New(elemType).Elem()
+		assignRow(row, item)
+		slice = reflect.Append(slice, item)
+	}
+
+	sliceVal.Set(slice)
+	return nil
+}
@@ -0,0 +1,257 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sqliteTimeLayouts are the layouts sqlite stores time.Time columns in when
+// the driver hands them back as strings instead of time.Time values.
+var sqliteTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02",
+}
+
+// scanPlan caches how to map a result set's columns onto a struct type's
+// fields, so the mapping is computed once per (columns, struct type) pair
+// rather than re-reflected for every row.
+type scanPlan struct {
+	// fieldIndex[i] is the struct field index path for columns[i], or nil
+	// if the column has no matching field and should be skipped.
+	fieldIndex [][]int
+}
+
+var (
+	scanPlanCache   = map[string]*scanPlan{}
+	scanPlanCacheMu sync.Mutex
+)
+
+// planFor returns the cached scanPlan for (t, columns), building and
+// caching it on first use.
+func planFor(t reflect.Type, columns []string) *scanPlan {
+	key := t.String() + "|" + strings.Join(columns, ",")
+
+	scanPlanCacheMu.Lock()
+	defer scanPlanCacheMu.Unlock()
+
+	if p, ok := scanPlanCache[key]; ok {
+		return p
+	}
+
+	fieldByColumn := map[string][]int{}
+	collectDBFields(t, nil, fieldByColumn)
+
+	plan := &scanPlan{fieldIndex: make([][]int, len(columns))}
+	for i, col := range columns {
+		plan.fieldIndex[i] = fieldByColumn[col]
+	}
+
+	scanPlanCache[key] = plan
+	return plan
+}
+
+// collectDBFields walks t's fields (recursing into anonymous embedded
+// structs, at any depth, which represent "column groups"), recording each
+// field's column name - from its `db` tag, falling back to the lowercased
+// field name - and index path.
+func collectDBFields(t reflect.Type, prefix []int, out map[string][]int) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		path := append(append([]int{}, prefix...), i)
+
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			collectDBFields(f.Type, path, out)
+			continue
+		}
+
+		col := f.Tag.Get("db")
+		if col == "" {
+			col = strings.ToLower(f.Name)
+		}
+		out[col] = path
+	}
+}
+
+// assignColumn sets dest (addressed via its index path from a struct root)
+// to raw, handling sql.Null* wrapper types, time.Time parsed from sqlite's
+// string representation, and nullable pointer fields.
+func assignColumn(root reflect.Value, path []int, raw interface{}) error {
+	if raw == nil {
+		return nil
+	}
+
+	field := root.FieldByIndex(path)
+
+	// Nullable pointer field: allocate and recurse into the pointee.
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		return assignValue(field.Elem(), raw)
+	}
+
+	return assignValue(field, raw)
+}
+
+// assignValue assigns raw onto field, which must be addressable.
+func assignValue(field reflect.Value, raw interface{}) error {
+	// sql.Scanner implementations (sql.NullString, sql.NullInt64, ...).
+	if field.CanAddr() {
+		if scanner, ok := field.Addr().Interface().(sql.Scanner); ok {
+			return scanner.Scan(raw)
+		}
+	}
+
+	if field.Type() == reflect.TypeOf(time.Time{}) {
+		switch v := raw.(type) {
+		case time.Time:
+			field.Set(reflect.ValueOf(v))
+			return nil
+		case string:
+			for _, layout := range sqliteTimeLayouts {
+				if t, err := time.Parse(layout, v); err == nil {
+					field.Set(reflect.ValueOf(t))
+					return nil
+				}
+			}
+			return fmt.Errorf("orm: could not parse %q as a time.Time", v)
+		case []byte:
+			return assignValue(field, string(v))
+		}
+	}
+
+	rv := reflect.ValueOf(raw)
+	if rv.Type().ConvertibleTo(field.Type()) {
+		field.Set(rv.Convert(field.Type()))
+		return nil
+	}
+	if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 && field.Kind() == reflect.String {
+		field.SetString(string(raw.([]byte)))
+		return nil
+	}
+
+	return fmt.Errorf("orm: cannot assign %T to field of type %s", raw, field.Type())
+}
+
+// scanRowsInto runs query/args and scans every result row onto dest, which
+// must be a pointer to a struct (one row) or a pointer to a slice of
+// structs (every row). It returns the number of rows scanned.
+func scanRowsInto(ctx context.Context, query string, args []interface{}, dest interface{}) (int, error) {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return 0, fmt.Errorf("orm: dest must be a non-nil pointer")
+	}
+
+	isSlice := v.Elem().Kind() == reflect.Slice
+	var elemType reflect.Type
+	if isSlice {
+		elemType = v.Elem().Type().Elem()
+	} else {
+		elemType = v.Elem().Type()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return 0, fmt.Errorf("orm: dest must point to a struct or a slice of structs")
+	}
+
+	rows, err := GetDB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("query execution failed: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			fmt.Printf("Failed to close rows: %v\n", cerr)
+		}
+	}()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get columns: %w", err)
+	}
+	plan := planFor(elemType, columns)
+
+	var slice reflect.Value
+	if isSlice {
+		slice = reflect.MakeSlice(v.Elem().Type(), 0, 0)
+	}
+
+	count := 0
+	for rows.Next() {
+		raw := make([]interface{}, len(columns))
+		ptrs := make([]interface{}, len(columns))
+		for i := range raw {
+			ptrs[i] = &raw[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return count, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		target := reflect.New(elemType).Elem()
+		for i, path := range plan.fieldIndex {
+			if path == nil {
+				continue
+			}
+			if err := assignColumn(target, path, raw[i]); err != nil {
+				return count, err
+			}
+		}
+
+		if isSlice {
+			slice = reflect.Append(slice, target)
+		} else {
+			v.Elem().Set(target)
+		}
+		count++
+
+		if !isSlice {
+			break
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return count, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	if isSlice {
+		v.Elem().Set(slice)
+	}
+
+	return count, nil
+}
+
+// ExecuteInto runs qb's built query and scans every matching row into
+// dest, a pointer to a slice of structs.
+func (qb *QueryBuilder) ExecuteInto(ctx context.Context, dest interface{}) error {
+	query, args := qb.Build()
+	_, err := scanRowsInto(ctx, query, args, dest)
+	if err != nil {
+		return fmt.Errorf("orm: ExecuteInto: %w", err)
+	}
+	return nil
+}
+
+// ExecuteOne runs qb's built query (forcing LIMIT 1) and scans the first
+// matching row into dest, a pointer to a struct. It returns sql.ErrNoRows
+// if no row matched.
+func (qb *QueryBuilder) ExecuteOne(ctx context.Context, dest interface{}) error {
+	qb.Limit(1)
+	query, args := qb.Build()
+
+	n, err := scanRowsInto(ctx, query, args, dest)
+	if err != nil {
+		return fmt.Errorf("orm: ExecuteOne: %w", err)
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// maxTxRetries bounds how many times WithTx will re-run fn after a
+// retryable busy/serialization error.
+const maxTxRetries = 3
+
+// Tx mirrors the package-level Create/Update/Delete helpers, but routes
+// every statement through an in-flight transaction instead of the shared
+// *sql.DB.
+type Tx struct {
+	tx  *sql.Tx
+	ctx context.Context
+}
+
+// WithTx begins a transaction, invokes fn, commits on success, and rolls
+// back on error or panic. When the driver reports a transient busy or
+// serialization conflict (SQLITE_BUSY, postgres 40001/40P01, mysql deadlock
+// 1213), fn is retried with exponential backoff and jitter, up to
+// maxTxRetries times.
+func WithTx(ctx context.Context, opts *sql.TxOptions, fn func(*Tx) error) error {
+	var lastErr error
+
+	for attempt := 0; attempt <= maxTxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(retryBackoff(attempt))
+		}
+
+		err := runTx(ctx, opts, fn)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+
+		if !dialect.IsRetryable(err) {
+			return err
+		}
+	}
+
+	return fmt.Errorf("orm: transaction failed after %d retries: %w", maxTxRetries, lastErr)
+}
+
+// runTx executes a single attempt of fn inside its own transaction.
+func runTx(ctx context.Context, opts *sql.TxOptions, fn func(*Tx) error) (err error) {
+	sqlTx, err := GetDB().BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = sqlTx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			if rbErr := sqlTx.Rollback(); rbErr != nil {
+				err = fmt.Errorf("%w (rollback also failed: %v)", err, rbErr)
+			}
+			return
+		}
+		err = sqlTx.Commit()
+	}()
+
+	err = fn(&Tx{tx: sqlTx, ctx: ctx})
+	return err
+}
+
+// retryBackoff computes an exponential backoff with jitter for the given
+// (1-indexed) retry attempt.
+func retryBackoff(attempt int) time.Duration {
+	base := time.Duration(1<<uint(attempt-1)) * 50 * time.Millisecond
+	jitter := time.Duration(rand.Int63n(int64(base)))
+	return base + jitter
+}
+
+// Create inserts a single record within the transaction.
+func (t *Tx) Create(tableName string, data map[string]interface{}) error {
+	columns := []string{}
+	values := []interface{}{}
+	for col, val := range data {
+		columns = append(columns, col)
+		values = append(values, val)
+	}
+
+	placeholders := make([]string, len(data))
+	for i := range placeholders {
+		placeholders[i] = "?"
+	}
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", quoteIdent(tableName), strings.Join(quoteIdents(columns), ", "), strings.Join(placeholders, ", "))
+
+	if _, err := t.tx.ExecContext(t.ctx, dialect.Rebind(query), values...); err != nil {
+		return fmt.Errorf("failed to insert record: %w", err)
+	}
+	return nil
+}
+
+// Update updates an existing record within the transaction.
+func (t *Tx) Update(tableName string, data map[string]interface{}, where map[string]interface{}) error {
+	setClauses := []string{}
+	args := []interface{}{}
+	for col, val := range data {
+		setClauses = append(setClauses, fmt.Sprintf("%s = ?", quoteIdent(col)))
+		args = append(args, val)
+	}
+
+	whereClauses := []string{}
+	for col, val := range where {
+		whereClauses = append(whereClauses, fmt.Sprintf("%s = ?", quoteIdent(col)))
+		args = append(args, val)
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE %s", quoteIdent(tableName), strings.Join(setClauses, ", "), strings.Join(whereClauses, " AND "))
+
+	if _, err := t.tx.ExecContext(t.ctx, dialect.Rebind(query), args...); err != nil {
+		return fmt.Errorf("failed to update record: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a record within the transaction.
+func (t *Tx) Delete(tableName string, where map[string]interface{}) error {
+	whereClauses := []string{}
+	args := []interface{}{}
+	for col, val := range where {
+		whereClauses = append(whereClauses, fmt.Sprintf("%s = ?", quoteIdent(col)))
+		args = append(args, val)
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s", quoteIdent(tableName), strings.Join(whereClauses, " AND "))
+
+	if _, err := t.tx.ExecContext(t.ctx, dialect.Rebind(query), args...); err != nil {
+		return fmt.Errorf("failed to delete record: %w", err)
+	}
+	return nil
+}
+
+// Exec rebinds and runs a raw `?`-placeholder query within the
+// transaction. It exists for callers such as the migrations subpackage
+// that need to run DDL statements rather than the map-based
+// Create/Update/Delete helpers.
+func (t *Tx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return t.tx.ExecContext(t.ctx, dialect.Rebind(query), args...)
+}
+
+// Execute runs qb's built query within the transaction and returns the
+// results as []map[string]interface{}, mirroring QueryBuilder.Execute.
+func (t *Tx) Execute(qb *QueryBuilder) ([]map[string]interface{}, error) {
+	query, params := qb.Build()
+
+	rows, err := t.tx.QueryContext(t.ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("query execution failed: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Printf("Failed to close rows: %v\n", err)
+		}
+	}()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get columns: %w", err)
+	}
+
+	var results []map[string]interface{}
+	for rows.Next() {
+		columnsData := make([]interface{}, len(columns))
+		columnPointers := make([]interface{}, len(columns))
+		for i := range columnsData {
+			columnPointers[i] = &columnsData[i]
+		}
+		if err := rows.Scan(columnPointers...); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		rowMap := make(map[string]interface{})
+		for i, column := range columns {
+			rowMap[column] = columnsData[i]
+		}
+		results = append(results, rowMap)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return results, nil
+}